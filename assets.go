@@ -0,0 +1,398 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+)
+
+// appCSS and appSearchJS are the index page's styling and client-side
+// search runtime. They're extracted here (rather than left inline in
+// indexTemplate) so writeHashedAssets can content-hash them into
+// long-lived, cache-busted files under site/assets/.
+const appCSS = `:root {
+  --bg: #0f1117;
+  --bg-card: #1a1d27;
+  --bg-hover: #22263a;
+  --border: #2a2e3e;
+  --text: #e4e4e7;
+  --text-muted: #9ca3af;
+  --accent: #6366f1;
+  --accent-light: #818cf8;
+  --green: #22c55e;
+  --orange: #f59e0b;
+  --red: #ef4444;
+  --blue: #3b82f6;
+  --font: 'Inter', -apple-system, BlinkMacSystemFont, sans-serif;
+  --mono: 'JetBrains Mono', 'Fira Code', monospace;
+  --max-w: 1200px;
+  --radius: 8px;
+}
+* { margin: 0; padding: 0; box-sizing: border-box; }
+html { overflow-x: hidden; }
+body {
+  font-family: var(--font);
+  background: var(--bg);
+  color: var(--text);
+  line-height: 1.6;
+  -webkit-font-smoothing: antialiased;
+  overflow-x: hidden;
+}
+a { color: var(--accent-light); text-decoration: none; }
+a:hover { text-decoration: underline; }
+a:focus-visible { outline: 2px solid var(--accent-light); outline-offset: 2px; border-radius: 2px; }
+.container { max-width: var(--max-w); margin: 0 auto; padding: 0 24px; }
+.site-header {
+  border-bottom: 1px solid var(--border);
+  padding: 16px 0;
+  position: sticky;
+  top: 0;
+  background: var(--bg);
+  z-index: 100;
+}
+.site-header .container {
+  display: flex;
+  align-items: center;
+  justify-content: space-between;
+  gap: 16px;
+}
+.site-brand {
+  font-size: 18px;
+  font-weight: 700;
+  color: var(--text);
+  display: flex;
+  align-items: center;
+  gap: 8px;
+  white-space: nowrap;
+  flex-shrink: 0;
+}
+.site-brand:hover { text-decoration: none; color: var(--accent-light); }
+.site-brand svg { width: 24px; height: 24px; }
+.site-nav { display: flex; gap: 16px; align-items: center; }
+.site-nav a { color: var(--text-muted); font-size: 14px; font-weight: 500; white-space: nowrap; }
+.site-nav a:hover { color: var(--text); text-decoration: none; }
+.hero {
+  padding: 64px 0 48px;
+  text-align: center;
+}
+.hero h1 {
+  font-size: 36px;
+  font-weight: 700;
+  margin-bottom: 12px;
+}
+.hero p {
+  color: var(--text-muted);
+  font-size: 18px;
+  max-width: 600px;
+  margin: 0 auto;
+}
+.hero-stats {
+  display: flex;
+  justify-content: center;
+  gap: 32px;
+  margin-top: 32px;
+}
+.hero-stat { text-align: center; }
+.hero-stat .num {
+  font-size: 28px;
+  font-weight: 700;
+  color: var(--accent-light);
+}
+.hero-stat .label {
+  font-size: 13px;
+  color: var(--text-muted);
+}
+.search-box {
+  max-width: 480px;
+  margin: 24px auto 0;
+  position: relative;
+}
+.search-input {
+  width: 100%;
+  padding: 10px 16px 10px 40px;
+  background: var(--bg-card);
+  border: 1px solid var(--border);
+  border-radius: var(--radius);
+  color: var(--text);
+  font-size: 14px;
+  font-family: inherit;
+  outline: none;
+  transition: border-color 0.2s;
+}
+.search-input:focus { border-color: var(--accent); }
+.search-input::placeholder { color: var(--text-muted); }
+.search-icon {
+  position: absolute;
+  left: 12px;
+  top: 50%;
+  transform: translateY(-50%);
+  width: 18px;
+  height: 18px;
+  color: var(--text-muted);
+  pointer-events: none;
+}
+.section-title {
+  font-size: 22px;
+  font-weight: 700;
+  margin-bottom: 16px;
+}
+.section-title a { color: var(--text); }
+.section-title a:hover { color: var(--accent-light); text-decoration: none; }
+.section { margin-bottom: 48px; }
+.card-grid {
+  display: grid;
+  grid-template-columns: repeat(auto-fill, minmax(340px, 1fr));
+  gap: 16px;
+}
+.card {
+  background: var(--bg-card);
+  border: 1px solid var(--border);
+  border-radius: var(--radius);
+  padding: 24px;
+  transition: border-color 0.2s;
+  display: flex;
+  flex-direction: column;
+}
+.card:hover {
+  border-color: var(--accent);
+  text-decoration: none;
+}
+.card-title {
+  font-size: 16px;
+  font-weight: 600;
+  color: var(--text);
+  margin-bottom: 8px;
+  display: flex;
+  align-items: center;
+  gap: 8px;
+}
+.card-title svg { width: 18px; height: 18px; flex-shrink: 0; color: var(--accent-light); }
+mark { background: var(--accent); color: var(--bg); border-radius: 2px; }
+.card-desc {
+  font-size: 14px;
+  color: var(--text-muted);
+  flex: 1;
+  margin-bottom: 12px;
+}
+.card-meta {
+  display: flex;
+  gap: 8px;
+  flex-wrap: wrap;
+  align-items: center;
+}
+.pill {
+  display: inline-flex;
+  align-items: center;
+  gap: 4px;
+  padding: 4px 10px;
+  background: var(--bg-card);
+  border: 1px solid var(--border);
+  border-radius: 20px;
+  font-size: 12px;
+  color: var(--text-muted);
+  font-weight: 500;
+}
+.pill-accent { border-color: var(--accent); color: var(--accent-light); }
+.pill-green { border-color: var(--green); color: var(--green); }
+.pill-blue { border-color: var(--blue); color: var(--blue); }
+.pill-orange { border-color: var(--orange); color: var(--orange); }
+.star-pill { border-color: var(--orange); color: var(--orange); }
+.card-news { font-size: 12px; color: var(--text-muted); margin-top: 10px; }
+.site-footer {
+  border-top: 1px solid var(--border);
+  padding: 32px 0;
+  margin-top: 64px;
+  color: var(--text-muted);
+  font-size: 13px;
+  text-align: center;
+}
+.no-results {
+  text-align: center;
+  color: var(--text-muted);
+  padding: 48px 0;
+  font-size: 15px;
+  display: none;
+}
+@media (max-width: 768px) {
+  .container { padding: 0 16px; }
+  .hero { padding: 40px 0 32px; }
+  .hero h1 { font-size: 24px; }
+  .hero p { font-size: 15px; }
+  .hero-stats { flex-wrap: wrap; gap: 12px; }
+  .card-grid { grid-template-columns: 1fr; }
+  .card { padding: 18px; }
+  .section-title { font-size: 18px; }
+  .site-footer { margin-top: 40px; padding: 24px 0; }
+}`
+
+const appSearchJS = `(function() {
+    // Field weight masks must match search.go's fieldWeight* constants.
+    var FIELD_WEIGHTS = { 1: 3.0, 2: 1.0, 4: 1.5, 8: 1.5 }; // name, desc, category, pill
+    var K1 = 1.2, B = 0.75;
+    var STOPWORDS = { a:1,an:1,and:1,are:1,as:1,at:1,be:1,by:1,for:1,from:1,has:1,in:1,is:1,it:1,of:1,on:1,or:1,that:1,the:1,to:1,was:1,will:1,with:1 };
+
+    function stem(s) {
+      if (s.length > 4 && s.slice(-3) === 'ies') return s.slice(0, -3) + 'y';
+      if (s.length > 5 && s.slice(-3) === 'ing') return s.slice(0, -3);
+      if (s.length > 4 && s.slice(-2) === 'ed') return s.slice(0, -2);
+      if (s.length > 4 && s.slice(-2) === 'es') return s.slice(0, -2);
+      if (s.length > 3 && s.slice(-1) === 's' && s.slice(-2) !== 'ss') return s.slice(0, -1);
+      if (s.length > 4 && s.slice(-2) === 'ly') return s.slice(0, -2);
+      return s;
+    }
+
+    function tokenize(s) {
+      return (s || '').toLowerCase().split(/[^a-z0-9]+/).filter(function(t) {
+        return t && !STOPWORDS[t];
+      }).map(stem);
+    }
+
+    var input = document.getElementById('search');
+    var noResults = document.getElementById('no-results');
+    var categorySections = document.getElementById('category-sections');
+    var resultsSection = document.getElementById('search-results');
+    var resultsGrid = document.getElementById('search-results-grid');
+
+    var index = null; // {docs, terms} once fetched
+    var avgDocLen = 0;
+    var docLens = null;
+
+    function ensureIndex() {
+      if (index) return Promise.resolve(index);
+      return fetch('/search-index.json').then(function(r) { return r.json(); }).then(function(data) {
+        index = data;
+        docLens = index.docs.map(function() { return 0; });
+        var total = 0;
+        Object.keys(index.terms).forEach(function(term) {
+          index.terms[term].forEach(function(p) {
+            docLens[p[0]] += p[2];
+          });
+        });
+        docLens.forEach(function(l) { total += l; });
+        avgDocLen = total / (docLens.length || 1) || 1;
+        return index;
+      });
+    }
+
+    function highlight(text, queryTokens) {
+      if (!queryTokens.length) return escapeHTML(text);
+      var words = text.split(/(\s+)/);
+      return words.map(function(w) {
+        var stripped = stem(w.toLowerCase().replace(/[^a-z0-9]/g, ''));
+        var isLast = queryTokens.lastToken && stripped.indexOf(queryTokens.lastToken) === 0;
+        if (queryTokens.indexOf(stripped) !== -1 || isLast) {
+          return '<mark>' + escapeHTML(w) + '</mark>';
+        }
+        return escapeHTML(w);
+      }).join('');
+    }
+
+    function escapeHTML(s) {
+      var div = document.createElement('div');
+      div.textContent = s;
+      return div.innerHTML;
+    }
+
+    function search(query) {
+      var tokens = tokenize(query);
+      if (!tokens.length) return [];
+      var lastToken = tokens[tokens.length - 1];
+      var scores = {};
+      var n = index.docs.length;
+
+      Object.keys(index.terms).forEach(function(term) {
+        var isLastPrefix = term.indexOf(lastToken) === 0;
+        var exactMatch = tokens.indexOf(term) !== -1;
+        if (!exactMatch && !isLastPrefix) return;
+
+        var postings = index.terms[term];
+        var idf = Math.log(1 + (n - postings.length + 0.5) / (postings.length + 0.5));
+
+        postings.forEach(function(p) {
+          var docId = p[0], mask = p[1], tf = p[2];
+          var dl = docLens[docId] || 1;
+          var tfNorm = (tf * (K1 + 1)) / (tf + K1 * (1 - B + B * (dl / avgDocLen)));
+          var fieldWeight = 0;
+          Object.keys(FIELD_WEIGHTS).forEach(function(bit) {
+            if (mask & bit) fieldWeight += FIELD_WEIGHTS[bit];
+          });
+          if (!fieldWeight) fieldWeight = 1;
+          var score = idf * tfNorm * fieldWeight;
+          if (isLastPrefix && !exactMatch) score *= 0.6; // prefix matches rank below exact terms
+          scores[docId] = (scores[docId] || 0) + score;
+        });
+      });
+
+      var ranked = Object.keys(scores).map(function(id) {
+        return { doc: index.docs[id], score: scores[id] };
+      });
+      ranked.sort(function(a, b) { return b.score - a.score; });
+      tokens.lastToken = lastToken;
+      return ranked.map(function(r) { return r.doc; }).map(function(doc) {
+        return { doc: doc, tokens: tokens };
+      });
+    }
+
+    function renderResults(results) {
+      resultsGrid.innerHTML = '';
+      results.forEach(function(r) {
+        var doc = r.doc;
+        var a = document.createElement('a');
+        a.href = doc.url;
+        a.className = 'card';
+        a.innerHTML =
+          '<div class="card-title">' + highlight(doc.name, r.tokens) + '</div>' +
+          '<div class="card-desc">' + highlight(doc.desc, r.tokens) + '</div>' +
+          '<div class="card-meta"><span class="pill">' + escapeHTML(doc.pill) + '</span></div>';
+        resultsGrid.appendChild(a);
+      });
+    }
+
+    input.addEventListener('input', function() {
+      var q = this.value.trim();
+      if (!q) {
+        resultsSection.style.display = 'none';
+        categorySections.style.display = '';
+        noResults.style.display = 'none';
+        return;
+      }
+      ensureIndex().then(function() {
+        var results = search(q);
+        categorySections.style.display = 'none';
+        resultsSection.style.display = results.length ? '' : 'none';
+        noResults.style.display = results.length ? 'none' : 'block';
+        renderResults(results);
+      });
+    });
+})();
+`
+
+// writeHashedAssets writes appCSS and appSearchJS to dir as
+// app.<hash>.css and app.<hash>.js, where <hash> is the first 8 hex
+// characters of the content's SHA-256, and returns their site-relative
+// public paths for use in <link>/<script> tags.
+func writeHashedAssets(dir string) (cssPath, jsPath string, err error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", "", err
+	}
+
+	cssName := "app." + contentHash(appCSS) + ".css"
+	if err := os.WriteFile(dir+"/"+cssName, []byte(appCSS), 0644); err != nil {
+		return "", "", fmt.Errorf("writing %s: %w", cssName, err)
+	}
+
+	jsName := "app." + contentHash(appSearchJS) + ".js"
+	if err := os.WriteFile(dir+"/"+jsName, []byte(appSearchJS), 0644); err != nil {
+		return "", "", fmt.Errorf("writing %s: %w", jsName, err)
+	}
+
+	return "/assets/" + cssName, "/assets/" + jsName, nil
+}
+
+// contentHash returns the first 8 hex characters of the SHA-256 of s,
+// used as a cache-busting filename suffix.
+func contentHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:8]
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// generateCategoryPages writes site/<slug>/index.html and
+// site/<slug>/sitemap.xml for each category, turning Category.Slug from an
+// unused data-section attribute into an actual browsable landing page.
+func generateCategoryPages(cfg Config, outDir string) error {
+	tmpl, err := template.New("category").Funcs(template.FuncMap{
+		"escape":     html.EscapeString,
+		"pathEscape": url.PathEscape,
+		"pillClass": func(s string) string {
+			if s == "" {
+				return "pill"
+			}
+			return "pill " + s
+		},
+	}).Parse(categoryTemplate)
+	if err != nil {
+		return fmt.Errorf("parsing category template: %w", err)
+	}
+
+	for i, cat := range cfg.Categories {
+		if cat.Slug == "" {
+			continue
+		}
+
+		dir := outDir + "/" + cat.Slug
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("creating %s: %w", dir, err)
+		}
+
+		var prev, next *Category
+		if i > 0 {
+			prev = &cfg.Categories[i-1]
+		}
+		if i < len(cfg.Categories)-1 {
+			next = &cfg.Categories[i+1]
+		}
+
+		data := struct {
+			Category
+			CanonicalURL string
+			Prev         *Category
+			Next         *Category
+		}{Category: cat, CanonicalURL: baseURL + "/" + cat.Slug + "/", Prev: prev, Next: next}
+
+		f, err := os.Create(dir + "/index.html")
+		if err != nil {
+			return fmt.Errorf("creating %s/index.html: %w", dir, err)
+		}
+		err = tmpl.Execute(f, data)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("rendering %s/index.html: %w", dir, err)
+		}
+
+		if err := generateCategorySitemap(cat, outDir); err != nil {
+			return fmt.Errorf("generating %s sitemap: %w", cat.Slug, err)
+		}
+	}
+
+	return nil
+}
+
+// generateCategorySitemap writes site/<slug>/sitemap.xml listing the
+// category page itself plus every repo it contains.
+func generateCategorySitemap(cat Category, outDir string) error {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	b.WriteString(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">` + "\n")
+	b.WriteString(fmt.Sprintf("  <url>\n    <loc>%s/%s/</loc>\n  </url>\n", baseURL, url.PathEscape(cat.Slug)))
+	for _, repo := range cat.Repos {
+		b.WriteString(fmt.Sprintf("  <url>\n    <loc>%s/%s/</loc>\n  </url>\n", baseURL, url.PathEscape(repo.Name)))
+	}
+	b.WriteString("</urlset>\n")
+	return os.WriteFile(outDir+"/"+cat.Slug+"/sitemap.xml", []byte(b.String()), 0644)
+}
+
+const categoryTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>{{.Name}} — Supermodel Tools</title>
+  <meta name="description" content="{{escape .Description}}">
+  <link rel="canonical" href="{{escape .CanonicalURL}}">
+  <meta property="og:type" content="website">
+  <meta property="og:title" content="{{escape .Name}} — Supermodel Tools">
+  <meta property="og:description" content="{{escape .Description}}">
+  <meta property="og:url" content="{{escape .CanonicalURL}}">
+  {{if .OGImage}}<meta property="og:image" content="{{escape .OGImage}}">{{end}}
+  <meta name="twitter:card" content="{{if .OGImage}}summary_large_image{{else}}summary{{end}}">
+  <meta name="twitter:title" content="{{escape .Name}} — Supermodel Tools">
+  <meta name="twitter:description" content="{{escape .Description}}">
+  {{if .OGImage}}<meta name="twitter:image" content="{{escape .OGImage}}">{{end}}
+  <link href="https://fonts.googleapis.com/css2?family=Inter:wght@400;500;600;700&family=JetBrains+Mono:wght@400;500&display=swap" rel="stylesheet">
+  <style>
+:root {
+  --bg: #0f1117;
+  --bg-card: #1a1d27;
+  --border: #2a2e3e;
+  --text: #e4e4e7;
+  --text-muted: #9ca3af;
+  --accent: #6366f1;
+  --accent-light: #818cf8;
+  --font: 'Inter', -apple-system, BlinkMacSystemFont, sans-serif;
+  --max-w: 1200px;
+  --radius: 8px;
+}
+* { margin: 0; padding: 0; box-sizing: border-box; }
+body { font-family: var(--font); background: var(--bg); color: var(--text); line-height: 1.6; }
+a { color: var(--accent-light); text-decoration: none; }
+a:hover { text-decoration: underline; }
+.container { max-width: var(--max-w); margin: 0 auto; padding: 0 24px; }
+.site-header { border-bottom: 1px solid var(--border); padding: 16px 0; }
+.site-brand { font-size: 18px; font-weight: 700; color: var(--text); }
+.hero { padding: 48px 0 32px; }
+.hero h1 { font-size: 32px; font-weight: 700; margin-bottom: 12px; }
+.hero p { color: var(--text-muted); font-size: 16px; max-width: 640px; }
+.card-grid { display: grid; grid-template-columns: repeat(auto-fill, minmax(340px, 1fr)); gap: 16px; margin-bottom: 48px; }
+.card { background: var(--bg-card); border: 1px solid var(--border); border-radius: var(--radius); padding: 24px; display: flex; flex-direction: column; }
+.card:hover { border-color: var(--accent); text-decoration: none; }
+.card-title { font-size: 16px; font-weight: 600; color: var(--text); margin-bottom: 8px; }
+.card-desc { font-size: 14px; color: var(--text-muted); flex: 1; margin-bottom: 12px; }
+.pill { display: inline-flex; align-items: center; padding: 4px 10px; border: 1px solid var(--border); border-radius: 20px; font-size: 12px; color: var(--text-muted); }
+.cat-nav { display: flex; justify-content: space-between; border-top: 1px solid var(--border); padding: 24px 0; margin-top: 24px; font-size: 14px; }
+.cat-nav .spacer { flex: 1; }
+.site-footer { border-top: 1px solid var(--border); padding: 32px 0; margin-top: 24px; color: var(--text-muted); font-size: 13px; text-align: center; }
+  </style>
+</head>
+<body>
+  <header class="site-header">
+    <div class="container">
+      <a href="/" class="site-brand">Supermodel Tools</a>
+    </div>
+  </header>
+  <main>
+    <div class="container">
+      <div class="hero">
+        <h1>{{.Name}}</h1>
+        <p>{{.Description}}</p>
+      </div>
+      <div class="card-grid">
+        {{range .Repos}}
+        <a href="/{{pathEscape .Name}}/" class="card">
+          <div class="card-title">{{.Name}}</div>
+          <div class="card-desc">{{.Desc}}</div>
+          <span class="{{pillClass .PillClass}}">{{.Pill}}</span>
+        </a>
+        {{end}}
+      </div>
+      <div class="cat-nav">
+        <div>{{if .Prev}}&larr; <a href="/{{pathEscape .Prev.Slug}}/">{{.Prev.Name}}</a>{{end}}</div>
+        <div class="spacer"></div>
+        <div>{{if .Next}}<a href="/{{pathEscape .Next.Slug}}/">{{.Next.Name}}</a> &rarr;{{end}}</div>
+      </div>
+    </div>
+  </main>
+  <footer class="site-footer">
+    <div class="container">
+      <p><a href="/">&larr; Back to all categories</a></p>
+    </div>
+  </footer>
+</body>
+</html>
+`
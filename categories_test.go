@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGenerateCategoryPagesSkipsEmptySlug(t *testing.T) {
+	outDir := t.TempDir()
+	cfg := Config{Categories: []Category{
+		{Name: "No Slug", Description: "should be skipped"},
+		{Name: "Tools", Slug: "tools", Description: "Tools category"},
+	}}
+
+	if err := generateCategoryPages(cfg, outDir); err != nil {
+		t.Fatalf("generateCategoryPages: %v", err)
+	}
+
+	if _, err := os.Stat(outDir + "/tools/index.html"); err != nil {
+		t.Errorf("expected %s/tools/index.html to exist: %v", outDir, err)
+	}
+	entries, err := os.ReadDir(outDir)
+	if err != nil {
+		t.Fatalf("reading %s: %v", outDir, err)
+	}
+	for _, e := range entries {
+		if e.Name() != "tools" {
+			t.Errorf("unexpected output %q for category with no slug", e.Name())
+		}
+	}
+}
+
+func TestGenerateCategoryPagesEscapesAttributeFields(t *testing.T) {
+	outDir := t.TempDir()
+	cfg := Config{Categories: []Category{
+		{
+			Name:        "Tools",
+			Slug:        "tools",
+			Description: `"><script>alert(1)</script>`,
+			OGImage:     `x.png" onerror="alert(2)`,
+		},
+	}}
+
+	if err := generateCategoryPages(cfg, outDir); err != nil {
+		t.Fatalf("generateCategoryPages: %v", err)
+	}
+
+	data, err := os.ReadFile(outDir + "/tools/index.html")
+	if err != nil {
+		t.Fatalf("reading generated page: %v", err)
+	}
+	head := strings.SplitN(string(data), "<body>", 2)[0]
+
+	if strings.Contains(head, "<script>alert(1)</script>") {
+		t.Errorf("Description was not escaped in <head> attribute context:\n%s", head)
+	}
+	if strings.Contains(head, `onerror="alert(2)"`) {
+		t.Errorf("OGImage was not escaped in <head> attribute context:\n%s", head)
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// softwareSourceCode builds a schema.org SoftwareSourceCode node describing
+// a single repo card.
+func softwareSourceCode(cat Category, repo Repo) map[string]interface{} {
+	node := map[string]interface{}{
+		"@context":    "https://schema.org",
+		"@type":       "SoftwareSourceCode",
+		"name":        repo.Name,
+		"description": repo.Desc,
+		"url":         baseURL + "/" + repo.Name + "/",
+	}
+	if repo.Upstream != "" {
+		node["codeRepository"] = "https://github.com/" + repo.Upstream
+	}
+	if repo.Language != "" {
+		node["programmingLanguage"] = repo.Language
+	}
+	return node
+}
+
+// repoJSONLD renders softwareSourceCode as an inline <script> block. It is
+// registered as a template func so indexTemplate can emit one per card.
+func repoJSONLD(cat Category, repo Repo) (string, error) {
+	data, err := json.Marshal(softwareSourceCode(cat, repo))
+	if err != nil {
+		return "", err
+	}
+	return `<script type="application/ld+json">` + string(data) + `</script>`, nil
+}
+
+// itemListJSONLDNode builds the page-level ItemList node of every repo,
+// for the root index.
+func itemListJSONLDNode(cfg Config) map[string]interface{} {
+	var elements []map[string]interface{}
+	pos := 1
+	for _, cat := range cfg.Categories {
+		for _, repo := range cat.Repos {
+			elements = append(elements, map[string]interface{}{
+				"@type":    "ListItem",
+				"position": pos,
+				"url":      baseURL + "/" + repo.Name + "/",
+				"name":     repo.Name,
+			})
+			pos++
+		}
+	}
+	return map[string]interface{}{
+		"@context":        "https://schema.org",
+		"@type":           "ItemList",
+		"itemListElement": elements,
+	}
+}
+
+// itemListJSONLD renders itemListJSONLDNode as an inline <script> block.
+func itemListJSONLD(cfg Config) (string, error) {
+	data, err := json.Marshal(itemListJSONLDNode(cfg))
+	if err != nil {
+		return "", err
+	}
+	return `<script type="application/ld+json">` + string(data) + `</script>`, nil
+}
+
+// dataFeedJSONLDNode builds a DataFeed node whose dataFeedElement lists
+// each category as a DataFeedItem, for the root index.
+func dataFeedJSONLDNode(cfg Config) map[string]interface{} {
+	var elements []map[string]interface{}
+	for _, cat := range cfg.Categories {
+		elements = append(elements, map[string]interface{}{
+			"@type": "DataFeedItem",
+			"item": map[string]interface{}{
+				"@type": "CollectionPage",
+				"name":  cat.Name,
+				"url":   baseURL + "/" + cat.Slug + "/",
+			},
+		})
+	}
+	return map[string]interface{}{
+		"@context":        "https://schema.org",
+		"@type":           "DataFeed",
+		"dataFeedElement": elements,
+	}
+}
+
+// dataFeedJSONLD renders dataFeedJSONLDNode as an inline <script> block.
+func dataFeedJSONLD(cfg Config) (string, error) {
+	data, err := json.Marshal(dataFeedJSONLDNode(cfg))
+	if err != nil {
+		return "", err
+	}
+	return `<script type="application/ld+json">` + string(data) + `</script>`, nil
+}
+
+// validateJSONLD checks that every emitted schema.org node has the fields
+// required for its @type, so gaps in repos.yaml are caught at build time
+// rather than by an external structured-data linter after deploy.
+func validateJSONLD(cfg Config) error {
+	required := map[string][]string{
+		"SoftwareSourceCode": {"name", "description", "url"},
+		"ItemList":           {"itemListElement"},
+		"DataFeed":           {"dataFeedElement"},
+	}
+
+	check := func(node map[string]interface{}) error {
+		typ, _ := node["@type"].(string)
+		for _, field := range required[typ] {
+			v, ok := node[field]
+			if !ok || isEmptyJSONLDValue(v) {
+				return fmt.Errorf("%s node missing required field %q", typ, field)
+			}
+		}
+		return nil
+	}
+
+	for _, cat := range cfg.Categories {
+		for _, repo := range cat.Repos {
+			if err := check(softwareSourceCode(cat, repo)); err != nil {
+				return fmt.Errorf("repo %q: %w", repo.Name, err)
+			}
+		}
+	}
+
+	if err := check(itemListJSONLDNode(cfg)); err != nil {
+		return fmt.Errorf("index ItemList: %w", err)
+	}
+
+	if err := check(dataFeedJSONLDNode(cfg)); err != nil {
+		return fmt.Errorf("index DataFeed: %w", err)
+	}
+
+	return nil
+}
+
+// isEmptyJSONLDValue reports whether a JSON-LD field value should be
+// treated as missing: an empty string for scalar fields, or a nil/empty
+// slice for list-valued fields like itemListElement/dataFeedElement.
+func isEmptyJSONLDValue(v interface{}) bool {
+	switch val := v.(type) {
+	case string:
+		return val == ""
+	case []map[string]interface{}:
+		return len(val) == 0
+	default:
+		return false
+	}
+}
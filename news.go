@@ -0,0 +1,300 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yuin/goldmark"
+)
+
+// NewsEntry is one announcement parsed from a news/*.md file.
+type NewsEntry struct {
+	Slug      string
+	Title     string
+	Desc      string
+	Published time.Time
+	Author    string
+	Related   string // repo slug from the optional `r:` header line
+	BodyHTML  template.HTML
+}
+
+const newsHeaderTimeLayout = "2006-01-02 15:04"
+
+// loadNews parses every news/*.md file using the compact [HEADER]/[END]
+// format and returns entries sorted newest first. A missing news directory
+// is not an error — sites without a news/ folder just get no entries.
+func loadNews() ([]NewsEntry, error) {
+	paths, err := filepath.Glob("news/*.md")
+	if err != nil {
+		return nil, fmt.Errorf("globbing news/*.md: %w", err)
+	}
+
+	entries := make([]NewsEntry, 0, len(paths))
+	for _, path := range paths {
+		entry, err := parseNewsFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Published.After(entries[j].Published)
+	})
+
+	return entries, nil
+}
+
+// parseNewsFile reads one news entry: a `[HEADER]` block of `key: value`
+// lines terminated by `[END]`, followed by a markdown body.
+func parseNewsFile(path string) (NewsEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return NewsEntry{}, err
+	}
+
+	lines := strings.Split(string(data), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "[HEADER]" {
+		return NewsEntry{}, fmt.Errorf("missing [HEADER] marker")
+	}
+
+	entry := NewsEntry{
+		Slug: strings.TrimSuffix(filepath.Base(path), ".md"),
+	}
+
+	i := 1
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "[END]" {
+			i++
+			break
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "t":
+			entry.Title = value
+		case "d":
+			entry.Desc = value
+		case "p":
+			published, err := time.Parse(newsHeaderTimeLayout, value)
+			if err != nil {
+				return NewsEntry{}, fmt.Errorf("parsing p: %q: %w", value, err)
+			}
+			entry.Published = published
+		case "a":
+			entry.Author = value
+		case "r":
+			entry.Related = value
+		}
+	}
+
+	body := strings.Join(lines[i:], "\n")
+	var buf strings.Builder
+	if err := goldmark.Convert([]byte(body), &buf); err != nil {
+		return NewsEntry{}, fmt.Errorf("rendering markdown: %w", err)
+	}
+	entry.BodyHTML = template.HTML(buf.String())
+
+	return entry, nil
+}
+
+// generateNews writes site/news/index.html, one site/news/<slug>/index.html
+// per entry, and the site/feed.xml Atom feed.
+func generateNews(entries []NewsEntry, outDir string) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(outDir+"/news", 0755); err != nil {
+		return err
+	}
+
+	listTmpl, err := template.New("news-index").Parse(newsIndexTemplate)
+	if err != nil {
+		return fmt.Errorf("parsing news index template: %w", err)
+	}
+	f, err := os.Create(outDir + "/news/index.html")
+	if err != nil {
+		return err
+	}
+	err = listTmpl.Execute(f, entries)
+	f.Close()
+	if err != nil {
+		return fmt.Errorf("rendering news/index.html: %w", err)
+	}
+
+	entryTmpl, err := template.New("news-entry").Parse(newsEntryTemplate)
+	if err != nil {
+		return fmt.Errorf("parsing news entry template: %w", err)
+	}
+	for _, entry := range entries {
+		dir := outDir + "/news/" + entry.Slug
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		f, err := os.Create(dir + "/index.html")
+		if err != nil {
+			return err
+		}
+		err = entryTmpl.Execute(f, entry)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("rendering %s/index.html: %w", dir, err)
+		}
+	}
+
+	return generateNewsFeed(entries, outDir)
+}
+
+// newsChrome is the CSS shared with the category pages (categories.go),
+// giving the news list and entry pages the same dark theme and site
+// header/footer as the rest of the site instead of bare unstyled HTML.
+const newsChrome = `<meta name="viewport" content="width=device-width, initial-scale=1">
+  <link href="https://fonts.googleapis.com/css2?family=Inter:wght@400;500;600;700&family=JetBrains+Mono:wght@400;500&display=swap" rel="stylesheet">
+  <style>
+:root {
+  --bg: #0f1117;
+  --bg-card: #1a1d27;
+  --border: #2a2e3e;
+  --text: #e4e4e7;
+  --text-muted: #9ca3af;
+  --accent: #6366f1;
+  --accent-light: #818cf8;
+  --font: 'Inter', -apple-system, BlinkMacSystemFont, sans-serif;
+  --max-w: 1200px;
+  --radius: 8px;
+}
+* { margin: 0; padding: 0; box-sizing: border-box; }
+body { font-family: var(--font); background: var(--bg); color: var(--text); line-height: 1.6; }
+a { color: var(--accent-light); text-decoration: none; }
+a:hover { text-decoration: underline; }
+.container { max-width: var(--max-w); margin: 0 auto; padding: 0 24px; }
+.site-header { border-bottom: 1px solid var(--border); padding: 16px 0; }
+.site-brand { font-size: 18px; font-weight: 700; color: var(--text); }
+.hero { padding: 48px 0 32px; }
+.hero h1 { font-size: 32px; font-weight: 700; margin-bottom: 12px; }
+.news-list { list-style: none; margin-bottom: 48px; }
+.news-list li { border-bottom: 1px solid var(--border); padding: 16px 0; }
+.news-list time { color: var(--text-muted); font-size: 14px; }
+.news-meta { color: var(--text-muted); font-size: 14px; margin-bottom: 24px; }
+article { max-width: 640px; margin-bottom: 48px; }
+article h1, article h2, article h3 { margin: 24px 0 12px; }
+article p { margin-bottom: 12px; }
+.site-footer { border-top: 1px solid var(--border); padding: 32px 0; margin-top: 24px; color: var(--text-muted); font-size: 13px; text-align: center; }
+  </style>`
+
+const newsSiteHeader = `  <header class="site-header">
+    <div class="container">
+      <a href="/" class="site-brand">Supermodel Tools</a>
+    </div>
+  </header>`
+
+const newsSiteFooter = `  <footer class="site-footer">
+    <div class="container">
+      <p><a href="/">&larr; Back to all categories</a></p>
+    </div>
+  </footer>`
+
+// generateNewsFeed writes site/feed.xml, an Atom 1.0 feed of every news
+// entry.
+func generateNewsFeed(entries []NewsEntry, outDir string) error {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	b.WriteString(`<feed xmlns="http://www.w3.org/2005/Atom">` + "\n")
+	b.WriteString("  <title>Supermodel Tools Updates</title>\n")
+	b.WriteString(fmt.Sprintf("  <link href=%q rel=\"self\"/>\n", baseURL+"/feed.xml"))
+	b.WriteString(fmt.Sprintf("  <link href=%q/>\n", baseURL+"/"))
+	b.WriteString(fmt.Sprintf("  <id>tag:%s,2024:updates</id>\n", feedHost()))
+	if len(entries) > 0 {
+		b.WriteString(fmt.Sprintf("  <updated>%s</updated>\n", entries[0].Published.Format(time.RFC3339)))
+	}
+
+	for _, entry := range entries {
+		url := baseURL + "/news/" + entry.Slug + "/"
+		b.WriteString("  <entry>\n")
+		b.WriteString(fmt.Sprintf("    <title>%s</title>\n", escapeXMLText(entry.Title)))
+		b.WriteString(fmt.Sprintf("    <link href=%q/>\n", url))
+		b.WriteString(fmt.Sprintf("    <id>tag:%s,%d:news/%s</id>\n", feedHost(), entry.Published.Year(), entry.Slug))
+		b.WriteString(fmt.Sprintf("    <updated>%s</updated>\n", entry.Published.Format(time.RFC3339)))
+		if entry.Author != "" {
+			b.WriteString(fmt.Sprintf("    <author><name>%s</name></author>\n", escapeXMLText(entry.Author)))
+		}
+		b.WriteString(fmt.Sprintf("    <summary>%s</summary>\n", escapeXMLText(entry.Desc)))
+		b.WriteString("  </entry>\n")
+	}
+
+	b.WriteString("</feed>\n")
+	return os.WriteFile(outDir+"/feed.xml", []byte(b.String()), 0644)
+}
+
+// escapeXMLText escapes a string for use as XML element content.
+func escapeXMLText(s string) string {
+	var buf strings.Builder
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+func feedHost() string {
+	return strings.TrimPrefix(strings.TrimPrefix(baseURL, "https://"), "http://")
+}
+
+const newsIndexTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Updates — Supermodel Tools</title>
+  <link rel="alternate" type="application/atom+xml" href="/feed.xml">
+  ` + newsChrome + `
+</head>
+<body>
+` + newsSiteHeader + `
+  <main>
+    <div class="container">
+      <div class="hero">
+        <h1>Updates</h1>
+      </div>
+      <ul class="news-list">
+        {{range .}}
+        <li><a href="/news/{{.Slug}}/">{{.Title}}</a> — <time>{{.Published.Format "Jan 2, 2006"}}</time></li>
+        {{end}}
+      </ul>
+    </div>
+  </main>
+` + newsSiteFooter + `
+</body>
+</html>
+`
+
+const newsEntryTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>{{.Title}} — Supermodel Tools</title>
+  ` + newsChrome + `
+</head>
+<body>
+` + newsSiteHeader + `
+  <main>
+    <div class="container">
+      <p><a href="/news/">&larr; All updates</a></p>
+      <div class="hero">
+        <h1>{{.Title}}</h1>
+        <p class="news-meta">{{.Published.Format "Jan 2, 2006"}}{{if .Author}} &middot; {{.Author}}{{end}}</p>
+      </div>
+      <article>{{.BodyHTML}}</article>
+    </div>
+  </main>
+` + newsSiteFooter + `
+</body>
+</html>
+`
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeNewsFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("writing %s: %v", path, err)
+	}
+	return path
+}
+
+func TestParseNewsFile(t *testing.T) {
+	dir := t.TempDir()
+
+	cases := []struct {
+		name    string
+		content string
+		want    NewsEntry
+		wantErr bool
+	}{
+		{
+			name: "full.md",
+			content: `[HEADER]
+t: Search launched
+d: Build-time search index is live
+p: 2024-03-01 09:30
+a: Jane Doe
+r: example-repo
+[END]
+Some **markdown** body.
+`,
+			want: NewsEntry{
+				Slug:      "full",
+				Title:     "Search launched",
+				Desc:      "Build-time search index is live",
+				Published: time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC),
+				Author:    "Jane Doe",
+				Related:   "example-repo",
+			},
+		},
+		{
+			name: "minimal.md",
+			content: `[HEADER]
+t: Minimal entry
+d: No author or related repo
+p: 2024-01-15 12:00
+[END]
+Body text.
+`,
+			want: NewsEntry{
+				Slug:      "minimal",
+				Title:     "Minimal entry",
+				Desc:      "No author or related repo",
+				Published: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "missing-header.md",
+			content: "t: No header marker\n[END]\nBody.\n",
+			wantErr: true,
+		},
+		{
+			name: "bad-date.md",
+			content: `[HEADER]
+t: Bad date
+d: desc
+p: not-a-date
+[END]
+Body.
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		path := writeNewsFile(t, dir, c.name, c.content)
+		entry, err := parseNewsFile(path)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("parseNewsFile(%s): expected error, got none", c.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("parseNewsFile(%s): unexpected error: %v", c.name, err)
+		}
+		if entry.Slug != c.want.Slug || entry.Title != c.want.Title || entry.Desc != c.want.Desc ||
+			!entry.Published.Equal(c.want.Published) || entry.Author != c.want.Author || entry.Related != c.want.Related {
+			t.Errorf("parseNewsFile(%s) = %+v, want %+v", c.name, entry, c.want)
+		}
+		if entry.BodyHTML == "" {
+			t.Errorf("parseNewsFile(%s): expected non-empty BodyHTML", c.name)
+		}
+	}
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// pipelineVersion is folded into every artifact's fingerprint so a binary
+// rebuild (template or generator logic changed) invalidates the manifest
+// even when repos.yaml and news/ are untouched.
+const pipelineVersion = "1"
+
+const manifestPath = ".cache/manifest.json"
+
+// Artifact is one unit of the Execute phase: a named output with a
+// fingerprint over its inputs and a function that (re)builds it into
+// outDir. Outputs lists every file it writes, relative to outDir, so
+// Execute can copy them forward from the previous build when the
+// fingerprint hasn't changed.
+type Artifact struct {
+	Name        string
+	Outputs     []string
+	Fingerprint string
+	Build       func(outDir string) error
+}
+
+// Plan walks cfg and news and returns the full list of artifacts this
+// build produces, each carrying the fingerprint of the inputs it was
+// planned from.
+func Plan(cfg Config, news []NewsEntry, cssPath, jsPath string) ([]Artifact, error) {
+	var artifacts []Artifact
+
+	indexInputs, err := fingerprintJSON("index", pipelineVersion, cfg, news, cssPath, jsPath)
+	if err != nil {
+		return nil, err
+	}
+	artifacts = append(artifacts, Artifact{
+		Name:        "index",
+		Outputs:     []string{"index.html"},
+		Fingerprint: indexInputs,
+		Build: func(outDir string) error {
+			return generateIndex(cfg, news, outDir, cssPath, jsPath)
+		},
+	})
+
+	starsStrippedCfg := stripStarsFields(cfg)
+
+	sitemapFP, err := fingerprintJSON("sitemap", pipelineVersion, starsStrippedCfg)
+	if err != nil {
+		return nil, err
+	}
+	artifacts = append(artifacts, Artifact{
+		Name:        "sitemap",
+		Outputs:     []string{"sitemap.xml"},
+		Fingerprint: sitemapFP,
+		Build:       func(outDir string) error { return generateSitemap(cfg, outDir) },
+	})
+
+	searchFP, err := fingerprintJSON("search", pipelineVersion, starsStrippedCfg)
+	if err != nil {
+		return nil, err
+	}
+	artifacts = append(artifacts, Artifact{
+		Name:        "search-index",
+		Outputs:     []string{"search-index.json"},
+		Fingerprint: searchFP,
+		Build:       func(outDir string) error { return generateSearchIndex(cfg, outDir) },
+	})
+
+	var categoryOutputs []string
+	for _, cat := range cfg.Categories {
+		if cat.Slug == "" {
+			continue
+		}
+		categoryOutputs = append(categoryOutputs, cat.Slug+"/index.html", cat.Slug+"/sitemap.xml")
+	}
+	categoriesFP, err := fingerprintJSON("categories", pipelineVersion, starsStrippedCfg)
+	if err != nil {
+		return nil, err
+	}
+	artifacts = append(artifacts, Artifact{
+		Name:        "categories",
+		Outputs:     categoryOutputs,
+		Fingerprint: categoriesFP,
+		Build:       func(outDir string) error { return generateCategoryPages(cfg, outDir) },
+	})
+
+	var newsOutputs []string
+	for _, entry := range news {
+		newsOutputs = append(newsOutputs, "news/"+entry.Slug+"/index.html")
+	}
+	if len(news) > 0 {
+		newsOutputs = append(newsOutputs, "news/index.html", "feed.xml")
+	}
+	newsFP, err := fingerprintJSON("news", pipelineVersion, news)
+	if err != nil {
+		return nil, err
+	}
+	artifacts = append(artifacts, Artifact{
+		Name:        "news",
+		Outputs:     newsOutputs,
+		Fingerprint: newsFP,
+		Build:       func(outDir string) error { return generateNews(news, outDir) },
+	})
+
+	return artifacts, nil
+}
+
+// stripStarsFields returns a copy of cfg with the fields fetchStars
+// populates (Stars, PushedAt, Language, License) zeroed out. Sitemap,
+// search-index, and category-page generation don't render any of that
+// data, so a star-count or pushed-at drift between builds shouldn't bust
+// their fingerprints and force a rebuild.
+func stripStarsFields(cfg Config) Config {
+	stripped := Config{Categories: make([]Category, len(cfg.Categories))}
+	for ci, cat := range cfg.Categories {
+		cat.Repos = append([]Repo(nil), cat.Repos...)
+		for ri := range cat.Repos {
+			cat.Repos[ri].Stars = 0
+			cat.Repos[ri].PushedAt = ""
+			cat.Repos[ri].Language = ""
+			cat.Repos[ri].License = ""
+		}
+		stripped.Categories[ci] = cat
+	}
+	return stripped
+}
+
+// fingerprintJSON hashes the JSON encoding of name plus every input,
+// giving a stable per-artifact fingerprint without hand-rolling field
+// enumeration for each artifact type.
+func fingerprintJSON(name string, inputs ...interface{}) (string, error) {
+	h := sha256.New()
+	io.WriteString(h, name)
+	for _, in := range inputs {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return "", fmt.Errorf("fingerprinting %s: %w", name, err)
+		}
+		h.Write(data)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Execute runs each artifact's Build in a worker pool bounded by jobs,
+// skipping artifacts whose fingerprint matches the previous manifest by
+// copying their outputs forward from prevDir instead of rebuilding.
+func Execute(artifacts []Artifact, outDir, prevDir string, jobs int, manifest map[string]string) (map[string]string, error) {
+	if jobs <= 0 {
+		jobs = runtime.GOMAXPROCS(0)
+	}
+
+	newManifest := make(map[string]string, len(artifacts))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var firstErr error
+	sem := make(chan struct{}, jobs)
+
+	for _, a := range artifacts {
+		a := a
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if manifest[a.Name] == a.Fingerprint && copyArtifactOutputs(a, prevDir, outDir) {
+				mu.Lock()
+				newManifest[a.Name] = a.Fingerprint
+				mu.Unlock()
+				return
+			}
+
+			if err := a.Build(outDir); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("building %s: %w", a.Name, err)
+				}
+				mu.Unlock()
+				return
+			}
+
+			mu.Lock()
+			newManifest[a.Name] = a.Fingerprint
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return newManifest, nil
+}
+
+// copyArtifactOutputs copies every output of a from prevDir to outDir.
+// It returns false (leaving outDir untouched for that artifact) if any
+// output is missing from prevDir, so the caller falls back to a real
+// build.
+func copyArtifactOutputs(a Artifact, prevDir, outDir string) bool {
+	if prevDir == "" {
+		return false
+	}
+	for _, rel := range a.Outputs {
+		if err := copyFile(prevDir+"/"+rel, outDir+"/"+rel); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func copyFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dirOf(dst), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, 0644)
+}
+
+func dirOf(path string) string {
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i] == '/' {
+			return path[:i]
+		}
+	}
+	return "."
+}
+
+func loadManifest() map[string]string {
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return map[string]string{}
+	}
+	var manifest map[string]string
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return map[string]string{}
+	}
+	return manifest
+}
+
+func saveManifest(manifest map[string]string) error {
+	if err := os.MkdirAll(".cache", 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(manifestPath, data, 0644)
+}
+
+// Publish atomically swaps tmpDir into finalDir: the previous finalDir
+// (if any) is moved aside, tmpDir takes its place, and the old directory
+// is removed only once the swap has succeeded.
+func Publish(tmpDir, finalDir string) error {
+	staleDir := finalDir + ".old"
+	os.RemoveAll(staleDir)
+
+	if _, err := os.Stat(finalDir); err == nil {
+		if err := os.Rename(finalDir, staleDir); err != nil {
+			return fmt.Errorf("moving aside %s: %w", finalDir, err)
+		}
+	}
+
+	if err := os.Rename(tmpDir, finalDir); err != nil {
+		// Best-effort restore so a failed publish doesn't leave the site missing.
+		os.Rename(staleDir, finalDir)
+		return fmt.Errorf("publishing %s: %w", finalDir, err)
+	}
+
+	os.RemoveAll(staleDir)
+	return nil
+}
+
+// watch polls repos.yaml and news/*.md for changes every interval and
+// re-runs build on change, for local iteration without a manual rebuild
+// loop.
+func watch(build func() error, interval time.Duration) error {
+	lastFingerprint := ""
+	for {
+		fp, err := watchedInputsFingerprint()
+		if err != nil {
+			return err
+		}
+		if fp != lastFingerprint {
+			lastFingerprint = fp
+			if err := build(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error rebuilding: %v\n", err)
+			} else {
+				fmt.Println("Rebuilt site/")
+			}
+		}
+		time.Sleep(interval)
+	}
+}
+
+func watchedInputsFingerprint() (string, error) {
+	h := sha256.New()
+	stat := func(path string) {
+		if info, err := os.Stat(path); err == nil {
+			fmt.Fprintf(h, "%s:%d:%d\n", path, info.Size(), info.ModTime().UnixNano())
+		}
+	}
+	stat("repos.yaml")
+	entries, _ := os.ReadDir("news")
+	for _, e := range entries {
+		stat("news/" + e.Name())
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStripStarsFields(t *testing.T) {
+	cfg := Config{Categories: []Category{
+		{Name: "Tools", Repos: []Repo{
+			{Name: "repo", Stars: 100, PushedAt: "2024-01-01T00:00:00Z", Language: "Go", License: "MIT"},
+		}},
+	}}
+
+	stripped := stripStarsFields(cfg)
+
+	repo := stripped.Categories[0].Repos[0]
+	if repo.Stars != 0 || repo.PushedAt != "" || repo.Language != "" || repo.License != "" {
+		t.Errorf("stripStarsFields left star fields populated: %+v", repo)
+	}
+	if repo.Name != "repo" {
+		t.Errorf("stripStarsFields dropped a non-star field: %+v", repo)
+	}
+	if cfg.Categories[0].Repos[0].Stars != 100 {
+		t.Errorf("stripStarsFields mutated the original cfg")
+	}
+}
+
+func TestFingerprintJSONStableAndSensitive(t *testing.T) {
+	cfg := Config{Categories: []Category{{Name: "Tools"}}}
+
+	fp1, err := fingerprintJSON("sitemap", pipelineVersion, cfg)
+	if err != nil {
+		t.Fatalf("fingerprintJSON: %v", err)
+	}
+	fp2, err := fingerprintJSON("sitemap", pipelineVersion, cfg)
+	if err != nil {
+		t.Fatalf("fingerprintJSON: %v", err)
+	}
+	if fp1 != fp2 {
+		t.Errorf("fingerprintJSON is not stable across identical inputs: %q != %q", fp1, fp2)
+	}
+
+	cfg.Categories[0].Name = "Other"
+	fp3, err := fingerprintJSON("sitemap", pipelineVersion, cfg)
+	if err != nil {
+		t.Fatalf("fingerprintJSON: %v", err)
+	}
+	if fp1 == fp3 {
+		t.Errorf("fingerprintJSON did not change when an input changed")
+	}
+}
+
+func TestExecuteSkipsUnchangedArtifacts(t *testing.T) {
+	prevDir := t.TempDir()
+	outDir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(prevDir, "out.txt"), []byte("cached"), 0644); err != nil {
+		t.Fatalf("writing prev output: %v", err)
+	}
+
+	built := false
+	artifacts := []Artifact{{
+		Name:        "thing",
+		Outputs:     []string{"out.txt"},
+		Fingerprint: "abc",
+		Build: func(outDir string) error {
+			built = true
+			return os.WriteFile(filepath.Join(outDir, "out.txt"), []byte("rebuilt"), 0644)
+		},
+	}}
+
+	manifest := map[string]string{"thing": "abc"}
+	newManifest, err := Execute(artifacts, outDir, prevDir, 1, manifest)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if built {
+		t.Errorf("Execute rebuilt an artifact whose fingerprint matched the manifest")
+	}
+	if newManifest["thing"] != "abc" {
+		t.Errorf("Execute dropped the matching fingerprint from the new manifest: %+v", newManifest)
+	}
+	data, err := os.ReadFile(filepath.Join(outDir, "out.txt"))
+	if err != nil {
+		t.Fatalf("reading copied output: %v", err)
+	}
+	if string(data) != "cached" {
+		t.Errorf("Execute did not copy forward the cached output, got %q", data)
+	}
+}
+
+func TestExecuteRebuildsOnFingerprintMismatch(t *testing.T) {
+	prevDir := t.TempDir()
+	outDir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(prevDir, "out.txt"), []byte("cached"), 0644); err != nil {
+		t.Fatalf("writing prev output: %v", err)
+	}
+
+	built := false
+	artifacts := []Artifact{{
+		Name:        "thing",
+		Outputs:     []string{"out.txt"},
+		Fingerprint: "new-fingerprint",
+		Build: func(outDir string) error {
+			built = true
+			return os.WriteFile(filepath.Join(outDir, "out.txt"), []byte("rebuilt"), 0644)
+		},
+	}}
+
+	manifest := map[string]string{"thing": "old-fingerprint"}
+	newManifest, err := Execute(artifacts, outDir, prevDir, 1, manifest)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if !built {
+		t.Errorf("Execute skipped a rebuild despite a fingerprint mismatch")
+	}
+	if newManifest["thing"] != "new-fingerprint" {
+		t.Errorf("Execute recorded the wrong fingerprint: %+v", newManifest)
+	}
+}
+
+func TestPublishSwapsTmpIntoFinal(t *testing.T) {
+	root := t.TempDir()
+	tmpDir := filepath.Join(root, "site.tmp")
+	finalDir := filepath.Join(root, "site")
+
+	if err := os.MkdirAll(finalDir, 0755); err != nil {
+		t.Fatalf("creating finalDir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(finalDir, "old.txt"), []byte("old"), 0644); err != nil {
+		t.Fatalf("writing old output: %v", err)
+	}
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		t.Fatalf("creating tmpDir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "new.txt"), []byte("new"), 0644); err != nil {
+		t.Fatalf("writing new output: %v", err)
+	}
+
+	if err := Publish(tmpDir, finalDir); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(finalDir, "new.txt")); err != nil {
+		t.Errorf("expected new.txt in finalDir: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(finalDir, "old.txt")); !os.IsNotExist(err) {
+		t.Errorf("expected old.txt to be gone from finalDir, err = %v", err)
+	}
+	if _, err := os.Stat(tmpDir); !os.IsNotExist(err) {
+		t.Errorf("expected tmpDir to be consumed by Publish, err = %v", err)
+	}
+	if _, err := os.Stat(finalDir + ".old"); !os.IsNotExist(err) {
+		t.Errorf("expected the stale directory to be cleaned up, err = %v", err)
+	}
+}
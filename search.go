@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SearchDoc is one searchable unit in the client-side index, mirroring the
+// fields a card exposes on the page.
+type SearchDoc struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Desc     string `json:"desc"`
+	Category string `json:"category"`
+	Pill     string `json:"pill"`
+	Upstream string `json:"upstream"`
+	URL      string `json:"url"`
+}
+
+// searchPosting is one occurrence of a term in a document: the document id,
+// a bitmask of which fields it was found in, and the term frequency within
+// that document across all matched fields.
+type searchPosting [3]int
+
+const (
+	fieldWeightName = 1 << iota
+	fieldWeightDesc
+	fieldWeightCategory
+	fieldWeightPill
+)
+
+// SearchIndex is the on-disk shape of site/search-index.json: the full
+// document list plus an inverted index mapping each stemmed term to its
+// postings.
+type SearchIndex struct {
+	Docs  []SearchDoc                `json:"docs"`
+	Terms map[string][]searchPosting `json:"terms"`
+}
+
+var tokenRe = regexp.MustCompile(`[^a-z0-9]+`)
+
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "has": true, "in": true,
+	"is": true, "it": true, "of": true, "on": true, "or": true, "that": true,
+	"the": true, "to": true, "was": true, "will": true, "with": true,
+}
+
+// stem applies a light Porter-subset suffix stripping pass. It is not a
+// full Porter implementation, just enough to fold plurals and common verb
+// endings so "indexing"/"indexed"/"indexes" collapse to "index".
+func stem(s string) string {
+	switch {
+	case strings.HasSuffix(s, "ies") && len(s) > 4:
+		return s[:len(s)-3] + "y"
+	case strings.HasSuffix(s, "ing") && len(s) > 5:
+		return s[:len(s)-3]
+	case strings.HasSuffix(s, "ed") && len(s) > 4:
+		return s[:len(s)-2]
+	case strings.HasSuffix(s, "es") && len(s) > 4:
+		return s[:len(s)-2]
+	case strings.HasSuffix(s, "s") && len(s) > 3 && !strings.HasSuffix(s, "ss"):
+		return s[:len(s)-1]
+	case strings.HasSuffix(s, "ly") && len(s) > 4:
+		return s[:len(s)-2]
+	}
+	return s
+}
+
+// tokenize lowercases s, splits on runs of non-alphanumeric characters,
+// drops stopwords, and stems what's left.
+func tokenize(s string) []string {
+	s = strings.ToLower(s)
+	raw := tokenRe.Split(s, -1)
+	tokens := make([]string, 0, len(raw))
+	for _, t := range raw {
+		if t == "" || stopwords[t] {
+			continue
+		}
+		tokens = append(tokens, stem(t))
+	}
+	return tokens
+}
+
+// buildSearchIndex flattens cfg into search documents and an inverted
+// index keyed by stemmed term. Each posting records which fields a term
+// occurred in (as a bitmask) and how many times, so the client runtime can
+// apply per-field BM25 weights without re-tokenizing on every keystroke.
+func buildSearchIndex(cfg Config) SearchIndex {
+	idx := SearchIndex{Terms: map[string][]searchPosting{}}
+
+	type fieldTokens struct {
+		weight int
+		tokens []string
+	}
+
+	docID := 0
+	for _, cat := range cfg.Categories {
+		for _, repo := range cat.Repos {
+			doc := SearchDoc{
+				ID:       docID,
+				Name:     repo.Name,
+				Desc:     repo.Desc,
+				Category: cat.Name,
+				Pill:     repo.Pill,
+				Upstream: repo.Upstream,
+				URL:      "/" + repo.Name + "/",
+			}
+			idx.Docs = append(idx.Docs, doc)
+
+			fields := []fieldTokens{
+				{fieldWeightName, tokenize(repo.Name)},
+				{fieldWeightDesc, tokenize(repo.Desc)},
+				{fieldWeightCategory, tokenize(cat.Name)},
+				{fieldWeightPill, tokenize(repo.Pill)},
+			}
+
+			counts := map[string]int{}
+			masks := map[string]int{}
+			for _, f := range fields {
+				for _, tok := range f.tokens {
+					counts[tok]++
+					masks[tok] |= f.weight
+				}
+			}
+			for tok, tf := range counts {
+				idx.Terms[tok] = append(idx.Terms[tok], searchPosting{docID, masks[tok], tf})
+			}
+
+			docID++
+		}
+	}
+
+	for _, postings := range idx.Terms {
+		sort.Slice(postings, func(i, j int) bool { return postings[i][0] < postings[j][0] })
+	}
+
+	return idx
+}
+
+// generateSearchIndex writes site/search-index.json, the document list and
+// inverted index the client-side search runtime fetches on first keystroke.
+func generateSearchIndex(cfg Config, outDir string) error {
+	idx := buildSearchIndex(cfg)
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outDir+"/search-index.json", data, 0644)
+}
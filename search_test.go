@@ -0,0 +1,47 @@
+package main
+
+import "testing"
+
+func TestStem(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"indexing", "index"},
+		{"indexed", "index"},
+		{"indexes", "index"},
+		{"categories", "category"},
+		{"quickly", "quick"},
+		{"pass", "pass"},
+		{"bus", "bus"},
+		{"go", "go"},
+	}
+	for _, c := range cases {
+		if got := stem(c.in); got != c.want {
+			t.Errorf("stem(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	cases := []struct {
+		in   string
+		want []string
+	}{
+		{"Build-Time Search Index", []string{"build", "time", "search", "index"}},
+		{"A tool for the indexing of repos", []string{"tool", "index", "repo"}},
+		{"", nil},
+		{"---", nil},
+	}
+	for _, c := range cases {
+		got := tokenize(c.in)
+		if len(got) != len(c.want) {
+			t.Fatalf("tokenize(%q) = %v, want %v", c.in, got, c.want)
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				t.Errorf("tokenize(%q)[%d] = %q, want %q", c.in, i, got[i], c.want[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// starCacheEntry is one upstream's cached GitHub metadata, keyed by
+// "owner/repo" in .cache/stars.json.
+type starCacheEntry struct {
+	Stars     int       `json:"stars"`
+	FetchedAt time.Time `json:"fetched_at"`
+	ETag      string    `json:"etag"`
+	PushedAt  string    `json:"pushed_at"`
+	Language  string    `json:"language"`
+	License   string    `json:"license"`
+}
+
+const starsCachePath = ".cache/stars.json"
+
+const starsWorkerCount = 8
+
+// githubAPIBase is the GitHub REST API base URL for repo lookups.
+// Overridable in tests to point at an httptest server.
+var githubAPIBase = "https://api.github.com/repos"
+
+// fetchStars populates Stars, PushedAt, Language, and License on every repo
+// with an Upstream set, backed by an on-disk cache at .cache/stars.json.
+// When refresh is true the cache is ignored and every repo is refetched.
+// When offline is true, repos missing from the cache are left at their
+// zero value instead of failing the build.
+func fetchStars(cfg *Config, refresh, offline bool) error {
+	cache, err := loadStarsCache()
+	if err != nil {
+		return fmt.Errorf("loading stars cache: %w", err)
+	}
+
+	type job struct {
+		repo *Repo
+	}
+
+	var jobs []job
+	for ci := range cfg.Categories {
+		for ri := range cfg.Categories[ci].Repos {
+			repo := &cfg.Categories[ci].Repos[ri]
+			if repo.Upstream != "" {
+				jobs = append(jobs, job{repo: repo})
+			}
+		}
+	}
+
+	token := os.Getenv("GITHUB_TOKEN")
+	jobCh := make(chan job)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var firstErr error
+
+	for i := 0; i < starsWorkerCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobCh {
+				mu.Lock()
+				entry, wasCached := cache[j.repo.Upstream]
+				mu.Unlock()
+				if refresh {
+					entry, wasCached = starCacheEntry{}, false
+				}
+
+				if offline && !wasCached {
+					continue
+				}
+
+				updated, err := fetchRepoMetadata(j.repo.Upstream, token, entry, wasCached && !refresh)
+				if err != nil {
+					if offline {
+						continue
+					}
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = fmt.Errorf("fetching %s: %w", j.repo.Upstream, err)
+					}
+					mu.Unlock()
+					continue
+				}
+
+				mu.Lock()
+				cache[j.repo.Upstream] = updated
+				mu.Unlock()
+
+				j.repo.Stars = updated.Stars
+				j.repo.PushedAt = updated.PushedAt
+				j.repo.Language = updated.Language
+				j.repo.License = updated.License
+			}
+		}()
+	}
+
+	for _, j := range jobs {
+		jobCh <- j
+	}
+	close(jobCh)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return saveStarsCache(cache)
+}
+
+// fetchRepoMetadata fetches a single repo from the GitHub REST API,
+// sending an If-None-Match conditional request when a cached ETag is
+// available, and retries with exponential backoff on rate limiting.
+func fetchRepoMetadata(upstream, token string, cached starCacheEntry, useConditional bool) (starCacheEntry, error) {
+	req, err := http.NewRequest("GET", githubAPIBase+"/"+upstream, nil)
+	if err != nil {
+		return starCacheEntry{}, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if useConditional && cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+
+	var resp *http.Response
+	backoff := time.Second
+	for attempt := 0; attempt < 5; attempt++ {
+		resp, err = http.DefaultClient.Do(req)
+		if err != nil {
+			return starCacheEntry{}, err
+		}
+
+		if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests {
+			resp.Body.Close()
+			time.Sleep(backoff)
+			backoff = time.Duration(math.Min(float64(backoff*2), float64(30*time.Second)))
+			continue
+		}
+		break
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		cached.FetchedAt = time.Now()
+		return cached, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return starCacheEntry{}, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		StargazersCount int    `json:"stargazers_count"`
+		PushedAt        string `json:"pushed_at"`
+		Language        string `json:"language"`
+		License         struct {
+			SPDXID string `json:"spdx_id"`
+		} `json:"license"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return starCacheEntry{}, err
+	}
+
+	return starCacheEntry{
+		Stars:     body.StargazersCount,
+		FetchedAt: time.Now(),
+		ETag:      resp.Header.Get("ETag"),
+		PushedAt:  body.PushedAt,
+		Language:  body.Language,
+		License:   body.License.SPDXID,
+	}, nil
+}
+
+func loadStarsCache() (map[string]starCacheEntry, error) {
+	data, err := os.ReadFile(starsCachePath)
+	if os.IsNotExist(err) {
+		return map[string]starCacheEntry{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cache map[string]starCacheEntry
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, err
+	}
+	return cache, nil
+}
+
+func saveStarsCache(cache map[string]starCacheEntry) error {
+	if err := os.MkdirAll(".cache", 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(starsCachePath, data, 0644)
+}
+
+// formatStars renders a star count the way GitHub does: 1200 -> "1.2k".
+func formatStars(n int) string {
+	if n < 1000 {
+		return fmt.Sprintf("%d", n)
+	}
+	return fmt.Sprintf("%.1fk", float64(n)/1000)
+}
+
+// humanizeSince renders an RFC3339 timestamp as a short relative duration,
+// e.g. "3 days ago".
+func humanizeSince(rfc3339 string) string {
+	t, err := time.Parse(time.RFC3339, rfc3339)
+	if err != nil {
+		return ""
+	}
+	d := time.Since(t)
+	switch {
+	case d < 24*time.Hour:
+		return "today"
+	case d < 48*time.Hour:
+		return "yesterday"
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%d days ago", int(d.Hours()/24))
+	case d < 365*24*time.Hour:
+		return fmt.Sprintf("%d months ago", int(d.Hours()/24/30))
+	default:
+		return fmt.Sprintf("%d years ago", int(d.Hours()/24/365))
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFetchRepoMetadata(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Header.Get("If-None-Match") == `"cached-etag"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"fresh-etag"`)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"stargazers_count": 42,
+			"pushed_at":        "2024-01-01T00:00:00Z",
+			"language":         "Go",
+			"license":          map[string]string{"spdx_id": "MIT"},
+		})
+	}))
+	defer server.Close()
+
+	origURL := githubAPIBase
+	githubAPIBase = server.URL
+	defer func() { githubAPIBase = origURL }()
+
+	entry, err := fetchRepoMetadata("owner/repo", "", starCacheEntry{}, false)
+	if err != nil {
+		t.Fatalf("fetchRepoMetadata: %v", err)
+	}
+	if entry.Stars != 42 || entry.Language != "Go" || entry.License != "MIT" || entry.ETag != `"fresh-etag"` {
+		t.Errorf("fetchRepoMetadata returned %+v", entry)
+	}
+
+	cached := starCacheEntry{Stars: 42, ETag: `"cached-etag"`, Language: "Go", License: "MIT"}
+	entry, err = fetchRepoMetadata("owner/repo", "", cached, true)
+	if err != nil {
+		t.Fatalf("fetchRepoMetadata (conditional): %v", err)
+	}
+	if entry.Stars != 42 {
+		t.Errorf("expected cached entry to be reused on 304, got %+v", entry)
+	}
+	if atomic.LoadInt32(&requests) != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
+
+func TestFetchStarsConcurrentCacheAccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"stargazers_count": 7})
+	}))
+	defer server.Close()
+
+	origURL := githubAPIBase
+	githubAPIBase = server.URL
+	defer func() { githubAPIBase = origURL }()
+
+	var categories []Category
+	for i := 0; i < 50; i++ {
+		categories = append(categories, Category{
+			Name: "cat",
+			Repos: []Repo{
+				{Name: "repo", Upstream: "owner/repo" + string(rune('a'+i%26))},
+			},
+		})
+	}
+	cfg := &Config{Categories: categories}
+
+	if err := fetchStars(cfg, false, false); err != nil {
+		t.Fatalf("fetchStars: %v", err)
+	}
+
+	for _, cat := range cfg.Categories {
+		for _, repo := range cat.Repos {
+			if repo.Stars != 7 {
+				t.Errorf("repo %s: Stars = %d, want 7", repo.Upstream, repo.Stars)
+			}
+		}
+	}
+}